@@ -0,0 +1,11 @@
+package dbr
+
+import "errors"
+
+// ErrBadDestination is returned (instead of panicking) when Load* is asked
+// to scan into something it can't: the wrong Go type, a slice/map element
+// that isn't a pointer to a struct, or -- unless the builder is Unsafe --
+// a result set whose columns don't line up with the destination struct's
+// fields. It's usually wrapped with fmt.Errorf's %w, so callers can still
+// errors.Is(err, dbr.ErrBadDestination) through the event-reporting layer.
+var ErrBadDestination = errors.New("dbr: invalid destination")
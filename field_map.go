@@ -0,0 +1,230 @@
+package dbr
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fieldMap maps a result-set column name to the index path (suitable for
+// reflect.Value.FieldByIndex) of the struct field it should be scanned into.
+// A multi-element path means the column lives on an embedded or nested
+// struct field.
+type fieldMap map[string][]int
+
+// typeMapping is the cached result of walking a struct type once: its
+// column field map, and (if one was found) the column named by a
+// `db:"...,key"` tag somewhere in the type.
+type typeMapping struct {
+	fields    fieldMap
+	keyColumn string
+	hasKeyTag bool
+}
+
+// fieldMapper caches typeMappings per reflect.Type so that the
+// struct-walking reflection below only happens once per type, not once per
+// query. This is the same trick sqlx's reflectx.Mapper uses.
+var fieldMapper = struct {
+	sync.RWMutex
+	cache map[reflect.Type]*typeMapping
+}{cache: make(map[reflect.Type]*typeMapping)}
+
+// mappingFor returns the (cached) typeMapping for t, building it on first use.
+func mappingFor(t reflect.Type) *typeMapping {
+	fieldMapper.RLock()
+	m, ok := fieldMapper.cache[t]
+	fieldMapper.RUnlock()
+	if ok {
+		return m
+	}
+
+	fieldMapper.Lock()
+	defer fieldMapper.Unlock()
+
+	// Another goroutine may have built it while we were waiting for the lock.
+	if m, ok := fieldMapper.cache[t]; ok {
+		return m
+	}
+
+	m = &typeMapping{fields: make(fieldMap)}
+	walkFields(t, nil, "", 0, m, make(map[string]int))
+	fieldMapper.cache[t] = m
+	return m
+}
+
+// fieldMapFor returns the (cached) fieldMap for t, building it on first use.
+func fieldMapFor(t reflect.Type) fieldMap {
+	return mappingFor(t).fields
+}
+
+// keyColumnTagFor returns the column named by a `db:"...,key"` tag
+// somewhere in t -- walking embedded and nested struct fields the same way
+// fieldMapFor does -- and whether one was found at all.
+func keyColumnTagFor(t reflect.Type) (string, bool) {
+	m := mappingFor(t)
+	return m.keyColumn, m.hasKeyTag
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// walkFields populates m with column -> index-path entries for every
+// scannable field of t, recursing into untagged anonymous embedded structs
+// (fields promoted as if declared directly on t) and into every other
+// struct field (columns namespaced as "prefix_column", where prefix is the
+// field's db tag or, absent one, its lowercased name, for loading joined
+// queries into composed structs). Unexported fields and fields tagged
+// `db:"-"` are skipped, except that an unexported embedded struct is still
+// walked for its exported fields. time.Time is treated as a leaf, not a
+// struct to recurse into. It also records the column of the first field
+// (by traversal order) tagged `db:"...,key"`, at any depth.
+//
+// Ambiguous column names resolve by depth, unconditionally: the field
+// declared at the shallower nesting level wins, regardless of which one t's
+// reflect.Type walks first. depths tracks the shallowest depth seen so far
+// per column so a deeper, later-visited duplicate can never clobber a
+// shallower one already recorded (real Go field-shadowing rules). Two
+// fields tied at the same depth are genuinely ambiguous -- Go itself refuses
+// to compile a selector for them -- and we just take the latest one visited,
+// which depth and namePrefix alone can't distinguish further.
+func walkFields(t reflect.Type, indexPrefix []int, namePrefix string, depth int, m *typeMapping, depths map[string]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if field.PkgPath != "" && !(field.Anonymous && ft.Kind() == reflect.Struct) {
+			continue // unexported
+		}
+
+		name, opts := parseTag(field.Tag.Get("db"))
+		if name == "-" {
+			continue
+		}
+
+		index := make([]int, len(indexPrefix)+1)
+		copy(index, indexPrefix)
+		index[len(indexPrefix)] = i
+
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			nestedPrefix := namePrefix
+			if !(field.Anonymous && name == "") {
+				nestedName := name
+				if nestedName == "" {
+					nestedName = strings.ToLower(field.Name)
+				}
+				nestedPrefix = joinColumnName(namePrefix, nestedName)
+			}
+			walkFields(ft, index, nestedPrefix, depth+1, m, depths)
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		column := joinColumnName(namePrefix, name)
+
+		if prevDepth, ok := depths[column]; !ok || depth <= prevDepth {
+			depths[column] = depth
+			m.fields[column] = index
+		}
+
+		if !m.hasKeyTag {
+			for _, opt := range opts {
+				if opt == "key" {
+					m.keyColumn = column
+					m.hasKeyTag = true
+					break
+				}
+			}
+		}
+	}
+}
+
+func joinColumnName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
+
+// parseTag splits a `db:"name,opt1,opt2"` tag into its column name and
+// options. An empty tag yields an empty name (callers fall back to the
+// lowercased field name).
+func parseTag(tag string) (name string, opts []string) {
+	if tag == "" {
+		return "", nil
+	}
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+// calculateFieldMap resolves columns (as returned by rows.Columns()) against
+// recordType's fieldMap, returning the index path to scan each column into
+// (or nil for a column with no matching field, which the caller ignores).
+// Unless quiet is true, it's an error for a query column to have no matching
+// struct field, or for a struct field (beyond those tagged `db:"-"`) to have
+// no matching query column. SelectBuilder.Unsafe passes quiet=true to
+// relax both checks.
+func (b *SelectBuilder) calculateFieldMap(recordType reflect.Type, columns []string, quiet bool) ([][]int, error) {
+	all := fieldMapFor(recordType)
+
+	result := make([][]int, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, column := range columns {
+		index, ok := all[column]
+		if !ok && !quiet {
+			return nil, fmt.Errorf("%w: column %q has no corresponding field on %s", ErrBadDestination, column, recordType)
+		}
+		result[i] = index
+		seen[column] = true
+	}
+
+	if !quiet {
+		for column := range all {
+			if !seen[column] {
+				return nil, fmt.Errorf("%w: field for column %q on %s not present in result set", ErrBadDestination, column, recordType)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// holderFor builds the []interface{} of field addresses that rows.Scan
+// writes into, one per column in fieldMap's order. Columns with no matching
+// field (a nil index, only possible when calculateFieldMap was called with
+// quiet=true) are scanned into a throwaway value.
+func (b *SelectBuilder) holderFor(record reflect.Value, fm [][]int) ([]interface{}, error) {
+	holder := make([]interface{}, len(fm))
+	for i, index := range fm {
+		if index == nil {
+			var ignored interface{}
+			holder[i] = &ignored
+			continue
+		}
+		holder[i] = fieldByIndexAlloc(record, index).Addr().Interface()
+	}
+	return holder, nil
+}
+
+// fieldByIndexAlloc is reflect.Value.FieldByIndex, except it allocates
+// through nil pointer-to-struct fields (embedded or nested) along the path
+// instead of panicking,
+// so that scanning into e.g. `Org *Org` works on the first row.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
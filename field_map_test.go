@@ -0,0 +1,118 @@
+package dbr
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fieldMapBase struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+type fieldMapDerived struct {
+	ID int `db:"id"`
+	fieldMapBase
+}
+
+type fieldMapDerivedEmbeddedFirst struct {
+	fieldMapBase
+	ID int `db:"id"`
+}
+
+type fieldMapOrg struct {
+	Name string `db:"name"`
+}
+
+type fieldMapComposed struct {
+	ID  int         `db:"id"`
+	Org fieldMapOrg `db:"org"`
+}
+
+type fieldMapSkipped struct {
+	ID     int    `db:"id"`
+	Ignore string `db:"-"`
+}
+
+type fieldMapKeyed struct {
+	Slug string `db:"slug,key"`
+	Name string `db:"name"`
+}
+
+type fieldMapEmbeddedKey struct {
+	fieldMapKeyed
+	Extra string `db:"extra"`
+}
+
+func TestFieldMapFor_Nested(t *testing.T) {
+	fm := fieldMapFor(reflect.TypeOf(fieldMapComposed{}))
+
+	if got, want := fm["id"], []int{0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("id index = %v, want %v", got, want)
+	}
+	if got, want := fm["org_name"], []int{1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("org_name index = %v, want %v", got, want)
+	}
+}
+
+func TestFieldMapFor_SkipsDashTag(t *testing.T) {
+	fm := fieldMapFor(reflect.TypeOf(fieldMapSkipped{}))
+
+	if _, ok := fm["ignore"]; ok {
+		t.Errorf("fieldMap should not contain a db:\"-\" field, got %v", fm)
+	}
+	if _, ok := fm["id"]; !ok {
+		t.Errorf("fieldMap missing id: %v", fm)
+	}
+}
+
+func TestFieldMapFor_ShallowerFieldWinsRegardlessOfDeclarationOrder(t *testing.T) {
+	fm := fieldMapFor(reflect.TypeOf(fieldMapDerived{}))
+
+	// fieldMapDerived.ID is declared before the embedded fieldMapBase that
+	// also promotes an "id" column, but declaration order must not matter:
+	// the shallower (directly declared) field always wins.
+	if got, want := fm["id"], []int{0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("id index = %v, want %v (fieldMapDerived.ID, not the promoted Base.ID)", got, want)
+	}
+
+	// The same holds with the embedded struct declared first.
+	fm = fieldMapFor(reflect.TypeOf(fieldMapDerivedEmbeddedFirst{}))
+	if got, want := fm["id"], []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("id index = %v, want %v (fieldMapDerivedEmbeddedFirst.ID, not the promoted Base.ID)", got, want)
+	}
+}
+
+func TestKeyColumnTagFor(t *testing.T) {
+	column, ok := keyColumnTagFor(reflect.TypeOf(fieldMapKeyed{}))
+	if !ok || column != "slug" {
+		t.Fatalf("keyColumnTagFor(fieldMapKeyed) = %q, %v, want \"slug\", true", column, ok)
+	}
+
+	column, ok = keyColumnTagFor(reflect.TypeOf(fieldMapEmbeddedKey{}))
+	if !ok || column != "slug" {
+		t.Fatalf("keyColumnTagFor(fieldMapEmbeddedKey) = %q, %v, want \"slug\", true (tag lives on an embedded field)", column, ok)
+	}
+
+	_, ok = keyColumnTagFor(reflect.TypeOf(fieldMapBase{}))
+	if ok {
+		t.Fatalf("keyColumnTagFor(fieldMapBase) found a key tag, want none")
+	}
+}
+
+func TestCalculateFieldMap_StrictMismatch(t *testing.T) {
+	var b *SelectBuilder
+	recordType := reflect.TypeOf(fieldMapBase{})
+
+	if _, err := b.calculateFieldMap(recordType, []string{"id", "bogus"}, false); err == nil {
+		t.Fatal("expected an error for an unmatched column, got nil")
+	}
+
+	if _, err := b.calculateFieldMap(recordType, []string{"id"}, false); err == nil {
+		t.Fatal("expected an error for an unmatched struct field, got nil")
+	}
+
+	if _, err := b.calculateFieldMap(recordType, []string{"id", "bogus"}, true); err != nil {
+		t.Fatalf("quiet=true should tolerate mismatches, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package dbr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindNamedValues(t *testing.T) {
+	cases := []struct {
+		name     string
+		sql      string
+		values   map[string]interface{}
+		wantSql  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "simple",
+			sql:      "id = :id AND status = :status",
+			values:   map[string]interface{}{"id": 1, "status": "active"},
+			wantSql:  "id = ? AND status = ?",
+			wantArgs: []interface{}{1, "active"},
+		},
+		{
+			name:     "repeated param",
+			sql:      ":x + :x",
+			values:   map[string]interface{}{"x": 5},
+			wantSql:  "? + ?",
+			wantArgs: []interface{}{5, 5},
+		},
+		{
+			name:     "postgres cast is left alone",
+			sql:      "foo::int = :id",
+			values:   map[string]interface{}{"id": 1},
+			wantSql:  "foo::int = ?",
+			wantArgs: []interface{}{1},
+		},
+		{
+			name:     "single-quoted region is not scanned for params",
+			sql:      "name = 'not:aparam' AND id = :id",
+			values:   map[string]interface{}{"id": 1},
+			wantSql:  "name = 'not:aparam' AND id = ?",
+			wantArgs: []interface{}{1},
+		},
+		{
+			name:     "double-quoted region is not scanned for params",
+			sql:      `"col:name" = :id`,
+			values:   map[string]interface{}{"id": 1},
+			wantSql:  `"col:name" = ?`,
+			wantArgs: []interface{}{1},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gotSql, gotArgs, err := bindNamedValues(c.sql, c.values)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotSql != c.wantSql {
+				t.Errorf("sql = %q, want %q", gotSql, c.wantSql)
+			}
+			if !reflect.DeepEqual(gotArgs, c.wantArgs) {
+				t.Errorf("args = %v, want %v", gotArgs, c.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBindNamedValues_MissingKey(t *testing.T) {
+	_, _, err := bindNamedValues("id = :id", map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected an error for a missing named parameter, got nil")
+	}
+}
+
+func TestNamedValues_Struct(t *testing.T) {
+	type filter struct {
+		ID     int    `db:"id"`
+		Name   string `db:"name"`
+		Hidden string `db:"-"`
+		Status string
+	}
+
+	values, err := namedValues(filter{ID: 1, Name: "a", Hidden: "nope", Status: "active"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{"id": 1, "name": "a", "status": "active"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("namedValues = %v, want %v", values, want)
+	}
+}
+
+func TestConvertKeyValue(t *testing.T) {
+	if v, ok := convertKeyValue(reflect.ValueOf(65), reflect.TypeOf("")); !ok || v.String() != "65" {
+		t.Errorf("convertKeyValue(65, string) = %v, %v, want \"65\", true", v, ok)
+	}
+
+	if v, ok := convertKeyValue(reflect.ValueOf(int32(7)), reflect.TypeOf(int64(0))); !ok || v.Int() != 7 {
+		t.Errorf("convertKeyValue(int32(7), int64) = %v, %v, want 7, true", v, ok)
+	}
+
+	if _, ok := convertKeyValue(reflect.ValueOf("abc"), reflect.TypeOf(0)); ok {
+		t.Error("convertKeyValue(string, int) should not succeed")
+	}
+}
@@ -0,0 +1,17 @@
+package dbr
+
+import (
+	"context"
+	"database/sql"
+)
+
+// runner is the thin slice of *sql.DB (or *sql.Tx, or a dbr Session/Tx) that
+// query building code needs to actually run a query. It's widened here to
+// include the Context-suffixed methods so Load* calls can honor a caller's
+// timeout or cancellation instead of running to completion unconditionally.
+type runner interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
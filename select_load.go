@@ -1,6 +1,8 @@
 package dbr
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 	"time"
 )
@@ -18,36 +20,44 @@ import (
 // - map of pointers to structures (addr of map also ok)
 // If it's a single structure, only the first record returned will be set.
 // If it's a slice or map, the slice/map won't be emptied first. New records will be allocated for each found record.
-// If its a map, there is the potential to overwrite values (keys are 'id')
+// If its a map, there is the potential to overwrite values (keys come from the "id" column by default)
 // Returns the number of items found (which is not necessarily the # of items set)
 func (b *SelectBuilder) LoadAll(dest interface{}) (int, error) {
-	//
-	// Validate the dest, and extract the reflection values we need.
-	//
-	valueOfDest := reflect.ValueOf(dest) // We want this to eventually be a map or slice
-	kindOfDest := valueOfDest.Kind()     // And this eventually needs to be a map or slice as well
+	return b.loadAll(context.Background(), dest, "", false)
+}
 
-	if kindOfDest == reflect.Ptr {
-		valueOfDest = reflect.Indirect(valueOfDest)
-		kindOfDest = valueOfDest.Kind()
-	} else if kindOfDest == reflect.Map {
-		// we're good
-	} else {
-		panic("invalid type passed to LoadAll. Need a map or addr of slice")
-	}
+// LoadAllByKey is LoadAll for a map destination where the key comes from
+// keyColumn instead of the default "id" column (or a `db:"...,key"` tagged
+// field). It's useful when the result set's primary key isn't named "id",
+// or a query needs to be keyed by some other unique column.
+func (b *SelectBuilder) LoadAllByKey(dest interface{}, keyColumn string) (int, error) {
+	return b.loadAll(context.Background(), dest, keyColumn, false)
+}
 
-	if !(kindOfDest == reflect.Map || kindOfDest == reflect.Slice) {
-		panic("invalid type passed to LoadAll. Need a map or addr of slice")
-	}
+// LoadAllContext is LoadAll, routed through the runner's QueryContext so ctx
+// can cancel or time out the query.
+func (b *SelectBuilder) LoadAllContext(ctx context.Context, dest interface{}) (int, error) {
+	return b.loadAll(ctx, dest, "", false)
+}
 
-	recordType := valueOfDest.Type().Elem()
-	if recordType.Kind() != reflect.Ptr {
-		panic("Elements need to be pointers to structures")
-	}
+// LoadAllByKeyContext is LoadAllByKey, routed through the runner's
+// QueryContext so ctx can cancel or time out the query.
+func (b *SelectBuilder) LoadAllByKeyContext(ctx context.Context, dest interface{}, keyColumn string) (int, error) {
+	return b.loadAll(ctx, dest, keyColumn, false)
+}
 
-	recordType = recordType.Elem()
-	if recordType.Kind() != reflect.Struct {
-		panic("Elements need to be pointers to structures")
+// loadAll is the shared implementation behind LoadAll, LoadAllByKey, their
+// Context variants, and UnsafeSelectBuilder's equivalents. keyColumn is only
+// consulted when dest is a map; "" means "work it out from the record
+// struct, defaulting to the 'id' column". quiet is passed straight through
+// to calculateFieldMap.
+func (b *SelectBuilder) loadAll(ctx context.Context, dest interface{}, keyColumn string, quiet bool) (int, error) {
+	//
+	// Validate the dest, and extract the reflection values we need.
+	//
+	valueOfDest, kindOfDest, recordType, err := validateLoadAllDest(dest)
+	if err != nil {
+		return 0, b.EventErr("dbr.select.load_all.bad_destination", err)
 	}
 
 	//
@@ -65,7 +75,7 @@ func (b *SelectBuilder) LoadAll(dest interface{}) (int, error) {
 	defer func() { b.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{"sql": fullSql}) }()
 
 	// Run the query:
-	rows, err := b.runner.Query(fullSql)
+	rows, err := b.runner.QueryContext(ctx, fullSql)
 	if err != nil {
 		return 0, b.EventErrKv("dbr.select.load_all.query", err, kvs{"sql": fullSql})
 	}
@@ -78,7 +88,7 @@ func (b *SelectBuilder) LoadAll(dest interface{}) (int, error) {
 	}
 
 	// Create a map of this result set to the struct fields
-	fieldMap, err := b.calculateFieldMap(recordType, columns, false)
+	fieldMap, err := b.calculateFieldMap(recordType, columns, quiet)
 	if err != nil {
 		return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.calculateFieldMap", err, kvs{"sql": fullSql})
 	}
@@ -110,7 +120,56 @@ func (b *SelectBuilder) LoadAll(dest interface{}) (int, error) {
 		}
 		valueOfDest.Set(sliceValue)
 	} else { // Map
+		mapType := valueOfDest.Type()
+		keyType := mapType.Key()
+
+		keyColumnName := keyColumnFor(recordType, keyColumn)
+		keyColumnIndex := -1
+		for i, column := range columns {
+			if column == keyColumnName {
+				keyColumnIndex = i
+				break
+			}
+		}
+		if keyColumnIndex == -1 {
+			err := fmt.Errorf("dbr: key column %q not found in result set", keyColumnName)
+			return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.key_column", err, kvs{"sql": fullSql})
+		}
+
+		keyFieldIndex := fieldMap[keyColumnIndex]
+		if keyFieldIndex == nil {
+			err := fmt.Errorf("dbr: key column %q has no corresponding struct field", keyColumnName)
+			return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.key_column", err, kvs{"sql": fullSql})
+		}
 
+		for rows.Next() {
+			// Create a new record to store our row:
+			pointerToNewRecord := reflect.New(recordType)
+			newRecord := reflect.Indirect(pointerToNewRecord)
+
+			// Build a 'holder', which is an []interface{}. Each value will be the address of the field corresponding to our newly made record:
+			holder, err := b.holderFor(newRecord, fieldMap)
+			if err != nil {
+				return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.holderFor", err, kvs{"sql": fullSql})
+			}
+
+			// Load up our new structure with the row's values
+			err = rows.Scan(holder...)
+			if err != nil {
+				return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.scan", err, kvs{"sql": fullSql})
+			}
+
+			keyValue, ok := convertKeyValue(newRecord.FieldByIndex(keyFieldIndex), keyType)
+			if !ok {
+				fieldType := newRecord.FieldByIndex(keyFieldIndex).Type()
+				err := fmt.Errorf("dbr: key column %q has type %s, which can't be used as a %s map key", keyColumnName, fieldType, keyType)
+				return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all.key_column", err, kvs{"sql": fullSql})
+			}
+
+			valueOfDest.SetMapIndex(keyValue, pointerToNewRecord)
+
+			numberOfRowsReturned += 1
+		}
 	}
 
 	// Check for errors at the end. Supposedly these are error that can happen during iteration.
@@ -123,15 +182,22 @@ func (b *SelectBuilder) LoadAll(dest interface{}) (int, error) {
 
 // Returns ErrNotFound if nothing was found
 func (b *SelectBuilder) LoadOne(dest interface{}) error {
+	return b.loadOne(context.Background(), dest, false)
+}
+
+// LoadOneContext is LoadOne, routed through the runner's QueryContext so ctx
+// can cancel or time out the query.
+func (b *SelectBuilder) LoadOneContext(ctx context.Context, dest interface{}) error {
+	return b.loadOne(ctx, dest, false)
+}
+
+func (b *SelectBuilder) loadOne(ctx context.Context, dest interface{}, quiet bool) error {
 	//
 	// Validate the dest, and extract the reflection values we need.
 	//
-	valueOfDest := reflect.ValueOf(dest)
-	indirectOfDest := reflect.Indirect(valueOfDest)
-	kindOfDest := valueOfDest.Kind()
-
-	if kindOfDest != reflect.Ptr || indirectOfDest.Kind() != reflect.Struct {
-		panic("you need to pass in the address of a struct")
+	indirectOfDest, err := validateLoadOneDest(dest)
+	if err != nil {
+		return b.EventErr("dbr.select.load_one.bad_destination", err)
 	}
 
 	recordType := indirectOfDest.Type()
@@ -149,7 +215,7 @@ func (b *SelectBuilder) LoadOne(dest interface{}) error {
 	defer func() { b.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{"sql": fullSql}) }()
 
 	// Run the query:
-	rows, err := b.runner.Query(fullSql)
+	rows, err := b.runner.QueryContext(ctx, fullSql)
 	if err != nil {
 		return b.EventErrKv("dbr.select.load_one.query", err, kvs{"sql": fullSql})
 	}
@@ -162,7 +228,7 @@ func (b *SelectBuilder) LoadOne(dest interface{}) error {
 	}
 
 	// Create a map of this result set to the struct columns
-	fieldMap, err := b.calculateFieldMap(recordType, columns, false)
+	fieldMap, err := b.calculateFieldMap(recordType, columns, quiet)
 	if err != nil {
 		return b.EventErrKv("dbr.select.load_one.calculateFieldMap", err, kvs{"sql": fullSql})
 	}
@@ -191,12 +257,19 @@ func (b *SelectBuilder) LoadOne(dest interface{}) error {
 
 // Returns ErrNotFound if no value was found, and it was therefore not set.
 func (b *SelectBuilder) LoadValue(dest interface{}) error {
-	// Validate the dest
-	valueOfDest := reflect.ValueOf(dest)
-	kindOfDest := valueOfDest.Kind()
+	return b.loadValue(context.Background(), dest)
+}
 
-	if kindOfDest != reflect.Ptr {
-		panic("Destination must be a pointer")
+// LoadValueContext is LoadValue, routed through the runner's QueryContext so
+// ctx can cancel or time out the query.
+func (b *SelectBuilder) LoadValueContext(ctx context.Context, dest interface{}) error {
+	return b.loadValue(ctx, dest)
+}
+
+func (b *SelectBuilder) loadValue(ctx context.Context, dest interface{}) error {
+	// Validate the dest
+	if err := validateLoadValueDest(dest); err != nil {
+		return b.EventErr("dbr.select.load_value.bad_destination", err)
 	}
 
 	//
@@ -212,7 +285,7 @@ func (b *SelectBuilder) LoadValue(dest interface{}) error {
 	defer func() { b.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{"sql": fullSql}) }()
 
 	// Run the query:
-	rows, err := b.runner.Query(fullSql)
+	rows, err := b.runner.QueryContext(ctx, fullSql)
 	if err != nil {
 		return b.EventErrKv("dbr.select.load_value.query", err, kvs{"sql": fullSql})
 	}
@@ -232,3 +305,118 @@ func (b *SelectBuilder) LoadValue(dest interface{}) error {
 
 	return ErrNotFound
 }
+
+// validateLoadAllDest checks that dest is a shape loadAll can use -- a map,
+// or the address of a slice, of pointers to some record struct -- and
+// returns the reflect.Value/Kind/Type loadAll needs to proceed. It's split
+// out from loadAll so this validation (in particular the nil-map check) can
+// be unit tested without a runner or a query to actually execute.
+func validateLoadAllDest(dest interface{}) (valueOfDest reflect.Value, kindOfDest reflect.Kind, recordType reflect.Type, err error) {
+	valueOfDest = reflect.ValueOf(dest) // We want this to eventually be a map or slice
+	kindOfDest = valueOfDest.Kind()     // And this eventually needs to be a map or slice as well
+
+	if kindOfDest == reflect.Ptr {
+		valueOfDest = reflect.Indirect(valueOfDest)
+		kindOfDest = valueOfDest.Kind()
+	} else if kindOfDest == reflect.Map {
+		// we're good
+	} else {
+		return reflect.Value{}, 0, nil, fmt.Errorf("%w: LoadAll needs a map or addr of slice, got %s", ErrBadDestination, reflect.TypeOf(dest))
+	}
+
+	if !(kindOfDest == reflect.Map || kindOfDest == reflect.Slice) {
+		return reflect.Value{}, 0, nil, fmt.Errorf("%w: LoadAll needs a map or addr of slice, got %s", ErrBadDestination, reflect.TypeOf(dest))
+	}
+
+	if kindOfDest == reflect.Map && valueOfDest.IsNil() {
+		return reflect.Value{}, 0, nil, fmt.Errorf("%w: LoadAll's destination map is nil", ErrBadDestination)
+	}
+
+	recordType = valueOfDest.Type().Elem()
+	if recordType.Kind() != reflect.Ptr {
+		return reflect.Value{}, 0, nil, fmt.Errorf("%w: LoadAll's elements need to be pointers to structures, got %s", ErrBadDestination, recordType)
+	}
+
+	recordType = recordType.Elem()
+	if recordType.Kind() != reflect.Struct {
+		return reflect.Value{}, 0, nil, fmt.Errorf("%w: LoadAll's elements need to be pointers to structures, got pointer to %s", ErrBadDestination, recordType)
+	}
+
+	return valueOfDest, kindOfDest, recordType, nil
+}
+
+// validateLoadOneDest checks that dest is the address of a struct, the
+// shape loadOne needs, returning the indirected struct value or
+// ErrBadDestination.
+func validateLoadOneDest(dest interface{}) (reflect.Value, error) {
+	valueOfDest := reflect.ValueOf(dest)
+	indirectOfDest := reflect.Indirect(valueOfDest)
+
+	if valueOfDest.Kind() != reflect.Ptr || indirectOfDest.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: LoadOne needs the address of a struct, got %s", ErrBadDestination, reflect.TypeOf(dest))
+	}
+
+	return indirectOfDest, nil
+}
+
+// validateLoadValueDest checks that dest is a pointer, the shape loadValue
+// needs, returning ErrBadDestination otherwise.
+func validateLoadValueDest(dest interface{}) error {
+	if reflect.ValueOf(dest).Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: LoadValue needs a pointer destination, got %s", ErrBadDestination, reflect.TypeOf(dest))
+	}
+	return nil
+}
+
+// keyColumnFor determines which column supplies a map key when loading into
+// a map[K]*T. explicit wins if given (LoadAllByKey). Otherwise it looks for
+// a field tagged `db:"...,key"` on recordType -- walking embedded and
+// nested struct fields the same way fieldMapFor does, so a key tag that
+// lives inside an embedded type is found too -- falling back to "id".
+func keyColumnFor(recordType reflect.Type, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+
+	if column, ok := keyColumnTagFor(recordType); ok {
+		return column
+	}
+
+	return "id"
+}
+
+// convertKeyValue converts v (a struct field read off a scanned row) to
+// keyType for use as a map key. Plain reflect.Value.Convert treats an
+// int->string conversion as "interpret the int as a Unicode code point"
+// (65 becomes "A", not "65"), which is never what a caller loading a
+// numeric id column into a map[string]*T wants, so numeric->string is
+// special-cased to format the decimal value instead. Beyond that,
+// conversion is restricted to same-kind-family (numeric<->numeric): a
+// looser conversion succeeding silently is more likely a destination-type
+// mistake than something the caller actually wants.
+func convertKeyValue(v reflect.Value, keyType reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(keyType) {
+		return v, true
+	}
+
+	if keyType.Kind() == reflect.String && isNumericKind(v.Kind()) {
+		return reflect.ValueOf(fmt.Sprint(v.Interface())).Convert(keyType), true
+	}
+
+	if isNumericKind(v.Kind()) && isNumericKind(keyType.Kind()) && v.Type().ConvertibleTo(keyType) {
+		return v.Convert(keyType), true
+	}
+
+	return reflect.Value{}, false
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,139 @@
+package dbr
+
+import (
+	"database/sql"
+	"time"
+)
+
+// normalizeMapValue converts any []byte value into a string, so LoadAllMaps
+// callers don't have to type-assert raw bytes themselves; everything else
+// passes through unchanged. It sees only the value, not the column type, so
+// bytes from a binary column are converted too.
+func normalizeMapValue(v interface{}) interface{} {
+	if raw, ok := v.([]byte); ok {
+		return string(raw)
+	}
+	return v
+}
+
+// LoadAllMaps runs the select and scans each row into a map[string]interface{}
+// keyed by column name, appending to *dest. It's meant for ad-hoc queries
+// where defining a destination struct isn't worth it (debug tooling,
+// CSV/JSON export, generic admin endpoints). Every []byte value is converted
+// to string so callers don't have to type-assert raw bytes themselves; the
+// column's type is not inspected, so binary columns come back as strings too.
+// Returns the number of rows found.
+func (b *SelectBuilder) LoadAllMaps(dest *[]map[string]interface{}) (int, error) {
+	//
+	// Get full SQL
+	//
+	fullSql, err := Interpolate(b.ToSql())
+	if err != nil {
+		return 0, b.EventErr("dbr.select.load_all_maps.interpolate", err)
+	}
+
+	// Start the timer:
+	startTime := time.Now()
+	defer func() { b.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{"sql": fullSql}) }()
+
+	// Run the query:
+	rows, err := b.runner.Query(fullSql)
+	if err != nil {
+		return 0, b.EventErrKv("dbr.select.load_all_maps.query", err, kvs{"sql": fullSql})
+	}
+	defer rows.Close()
+
+	// Get the columns returned
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, b.EventErrKv("dbr.select.load_all_maps.rows.Columns", err, kvs{"sql": fullSql})
+	}
+
+	numberOfRowsReturned := 0
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		holder := make([]interface{}, len(columns))
+		for i := range values {
+			holder[i] = &values[i]
+		}
+
+		if err := rows.Scan(holder...); err != nil {
+			return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all_maps.scan", err, kvs{"sql": fullSql})
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = normalizeMapValue(values[i])
+		}
+		*dest = append(*dest, row)
+
+		numberOfRowsReturned += 1
+	}
+
+	if err := rows.Err(); err != nil {
+		return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all_maps.rows_err", err, kvs{"sql": fullSql})
+	}
+
+	return numberOfRowsReturned, nil
+}
+
+// LoadAllMapStrings is LoadAllMaps, but every value is scanned as a string
+// (via sql.RawBytes, copied out before the underlying row buffer is
+// reused) rather than left as interface{}. NULLs scan as the empty string,
+// same as *sql.RawBytes always does.
+// Returns the number of rows found.
+func (b *SelectBuilder) LoadAllMapStrings(dest *[]map[string]string) (int, error) {
+	//
+	// Get full SQL
+	//
+	fullSql, err := Interpolate(b.ToSql())
+	if err != nil {
+		return 0, b.EventErr("dbr.select.load_all_map_strings.interpolate", err)
+	}
+
+	// Start the timer:
+	startTime := time.Now()
+	defer func() { b.TimingKv("dbr.select", time.Since(startTime).Nanoseconds(), kvs{"sql": fullSql}) }()
+
+	// Run the query:
+	rows, err := b.runner.Query(fullSql)
+	if err != nil {
+		return 0, b.EventErrKv("dbr.select.load_all_map_strings.query", err, kvs{"sql": fullSql})
+	}
+	defer rows.Close()
+
+	// Get the columns returned
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, b.EventErrKv("dbr.select.load_all_map_strings.rows.Columns", err, kvs{"sql": fullSql})
+	}
+
+	numberOfRowsReturned := 0
+
+	for rows.Next() {
+		values := make([]sql.RawBytes, len(columns))
+		holder := make([]interface{}, len(columns))
+		for i := range values {
+			holder[i] = &values[i]
+		}
+
+		if err := rows.Scan(holder...); err != nil {
+			return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all_map_strings.scan", err, kvs{"sql": fullSql})
+		}
+
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[column] = string(values[i])
+		}
+		*dest = append(*dest, row)
+
+		numberOfRowsReturned += 1
+	}
+
+	if err := rows.Err(); err != nil {
+		return numberOfRowsReturned, b.EventErrKv("dbr.select.load_all_map_strings.rows_err", err, kvs{"sql": fullSql})
+	}
+
+	return numberOfRowsReturned, nil
+}
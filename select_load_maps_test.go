@@ -0,0 +1,23 @@
+package dbr
+
+import "testing"
+
+// LoadAllMaps/LoadAllMapStrings can't be exercised end-to-end here for the
+// same reason as loadAll: they need a real *sql.Rows from a working
+// SelectBuilder (ToSql, EventReceiver wiring), which lives outside this
+// snapshot. normalizeMapValue is the one piece of their row-to-map
+// conversion that's pure and separable, so that's what's covered.
+
+func TestNormalizeMapValue(t *testing.T) {
+	if got := normalizeMapValue([]byte("hello")); got != "hello" {
+		t.Errorf("normalizeMapValue([]byte) = %v (%T), want %q", got, got, "hello")
+	}
+
+	if got := normalizeMapValue(42); got != 42 {
+		t.Errorf("normalizeMapValue(42) = %v, want 42 unchanged", got)
+	}
+
+	if got := normalizeMapValue(nil); got != nil {
+		t.Errorf("normalizeMapValue(nil) = %v, want nil", got)
+	}
+}
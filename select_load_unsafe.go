@@ -0,0 +1,55 @@
+package dbr
+
+import "context"
+
+// UnsafeSelectBuilder wraps a SelectBuilder so its Load* calls tolerate a
+// mismatch between the result set's columns and the destination struct's
+// fields -- a query column with no struct field, or vice versa -- instead of
+// returning ErrBadDestination. Get one from SelectBuilder.Unsafe; it's meant
+// for running SELECT * against a struct that only covers part of the table,
+// e.g. mid schema-migration, where failing loudly on every added/dropped
+// column would be more trouble than it's worth.
+type UnsafeSelectBuilder struct {
+	*SelectBuilder
+}
+
+// Unsafe returns an UnsafeSelectBuilder wrapping b.
+func (b *SelectBuilder) Unsafe() *UnsafeSelectBuilder {
+	return &UnsafeSelectBuilder{b}
+}
+
+// LoadAll is SelectBuilder.LoadAll, but column/field mismatches are ignored
+// instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadAll(dest interface{}) (int, error) {
+	return b.SelectBuilder.loadAll(context.Background(), dest, "", true)
+}
+
+// LoadAllByKey is SelectBuilder.LoadAllByKey, but column/field mismatches
+// are ignored instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadAllByKey(dest interface{}, keyColumn string) (int, error) {
+	return b.SelectBuilder.loadAll(context.Background(), dest, keyColumn, true)
+}
+
+// LoadAllContext is SelectBuilder.LoadAllContext, but column/field
+// mismatches are ignored instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadAllContext(ctx context.Context, dest interface{}) (int, error) {
+	return b.SelectBuilder.loadAll(ctx, dest, "", true)
+}
+
+// LoadAllByKeyContext is SelectBuilder.LoadAllByKeyContext, but column/field
+// mismatches are ignored instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadAllByKeyContext(ctx context.Context, dest interface{}, keyColumn string) (int, error) {
+	return b.SelectBuilder.loadAll(ctx, dest, keyColumn, true)
+}
+
+// LoadOne is SelectBuilder.LoadOne, but column/field mismatches are ignored
+// instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadOne(dest interface{}) error {
+	return b.SelectBuilder.loadOne(context.Background(), dest, true)
+}
+
+// LoadOneContext is SelectBuilder.LoadOneContext, but column/field
+// mismatches are ignored instead of returning ErrBadDestination.
+func (b *UnsafeSelectBuilder) LoadOneContext(ctx context.Context, dest interface{}) error {
+	return b.SelectBuilder.loadOne(ctx, dest, true)
+}